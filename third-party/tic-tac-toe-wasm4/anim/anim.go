@@ -0,0 +1,80 @@
+// Package anim provides a small frame-counter tween system, modeled on the
+// Croupier reposition package's frame-counter interpolation, for animating
+// sprite positions and sizes across WASM-4's fixed-step Update calls.
+package anim
+
+import "image"
+
+// Tween linearly interpolates a position and a "dimension" point (e.g. a
+// size, or a second independent axis) from Start/StartDim to End/EndDim
+// over Frames calls to Animator.Update, calling OnDone once it lands on the
+// last frame.
+type Tween struct {
+	Start, End       image.Point
+	StartDim, EndDim image.Point
+	Frames           int
+	OnDone           func()
+
+	iter int
+}
+
+// Done reports whether the tween has reached its last frame.
+func (t *Tween) Done() bool {
+	return t.Frames <= 0 || t.iter >= t.Frames
+}
+
+// At returns the tween's current interpolated position and dimension.
+func (t *Tween) At() (curXY, curDim image.Point) {
+	if t.Done() {
+		return t.End, t.EndDim
+	}
+
+	curXY = image.Pt(
+		t.Start.X+(t.End.X-t.Start.X)*t.iter/t.Frames,
+		t.Start.Y+(t.End.Y-t.Start.Y)*t.iter/t.Frames,
+	)
+	curDim = image.Pt(
+		t.StartDim.X+(t.EndDim.X-t.StartDim.X)*t.iter/t.Frames,
+		t.StartDim.Y+(t.EndDim.Y-t.StartDim.Y)*t.iter/t.Frames,
+	)
+	return curXY, curDim
+}
+
+// Animator owns a set of in-flight tweens and advances each by one frame
+// per Update call.
+type Animator struct {
+	tweens  []*Tween
+	pending []*Tween
+}
+
+// Add starts tracking t. Safe to call re-entrantly from a tween's OnDone:
+// the new tween is queued and only joins the live set once Update returns,
+// so it can't be clobbered by the in-progress Update call that triggered it.
+func (a *Animator) Add(t *Tween) {
+	a.pending = append(a.pending, t)
+}
+
+// Update advances every tracked tween by one frame, invoking OnDone for any
+// tween that lands on its last frame and dropping it from the set, then
+// folds in whatever Add queued during this call (including re-entrantly
+// from an OnDone).
+func (a *Animator) Update() {
+	live := make([]*Tween, 0, len(a.tweens))
+	for _, t := range a.tweens {
+		if t.Done() {
+			continue
+		}
+
+		t.iter++
+		if t.Done() && t.OnDone != nil {
+			t.OnDone()
+		}
+		if !t.Done() {
+			live = append(live, t)
+		}
+	}
+
+	live = append(live, a.pending...)
+	a.pending = nil
+	a.tweens = live
+}
@@ -1,28 +1,74 @@
 package main
 
 import (
+	"cart/anim"
+	"cart/scores"
 	"cart/utils"
 	"cart/w4"
 	"image"
+	"math"
 )
 
+// cellPixels is the pixel stride between adjacent board cells, matching the
+// `(pos.X*6+2)*8` placement math in Player.Draw.
+const cellPixels = 6 * 8
+
+// cpuMoveFrames is how long a CPU move's cursor tween takes to reach its
+// target cell before the move is actually committed.
+const cpuMoveFrames = 15
+
+// winBannerFrames is how long the win banner takes to slide down into place.
+const winBannerFrames = 20
+
+// historyHoldThreshold is how long BUTTON_2 must be held on the game-over
+// screen before it opens the match-history screen.
+const historyHoldThreshold = 40
+
 type Game struct {
-	board           [3][3]int
-	currentPlayer   int
-	cursor          image.Point
-	frameCount      int
-	animFrame       int
-	winner          int
-	seed            int64
-	totalFrameCount int64
-	rnd             func(uint) uint
-	stars           []Star
-	playerSprite    interface {
-		Draw(image.Point, bool)
+	board            [3][3]int
+	currentPlayer    int
+	cursor           image.Point
+	frameCount       int
+	animFrame        int
+	winner           int
+	seed             int64
+	totalFrameCount  int64
+	rnd              func(uint) uint
+	stars            []Star
+	difficulty       Difficulty
+	difficultyChosen bool
+	seatPlayer       [4]int
+	lobbyDone        bool
+	replay           Replay
+	history          scores.History
+	historyMode      bool
+	historyHeld      int
+	historyScroll    int
+	historyPlaying   int
+	anim             anim.Animator
+	cursorTween      *anim.Tween
+	bannerTween      *anim.Tween
+	playerSprite     interface {
+		Draw(image.Point, bool, image.Point)
 	}
 }
 
+// Difficulty selects how hard the CPU opponent plays.
+type Difficulty int
+
+const (
+	Easy Difficulty = iota
+	Normal
+	Hard
+)
+
+// normalSearchDepth bounds the search on Normal difficulty so the CPU
+// stays beatable; Hard searches to the bottom of the (at most 9-ply) tree.
+const normalSearchDepth = 4
+
 func (g *Game) Move(X, Y int) {
+	g.replay.record(g.currentPlayer, X, Y)
+
 	g.board[X][Y] = g.currentPlayer
 	g.currentPlayer = g.currentPlayer%2 + 1
 	var pos [3]image.Point
@@ -32,10 +78,50 @@ func (g *Game) Move(X, Y int) {
 		g.board[pos[1].X][pos[1].Y] += 2
 		g.board[pos[2].X][pos[2].Y] += 2
 	}
+
+	if g.winner != 0 {
+		g.replay.Winner, g.replay.Pos = g.winner, pos
+		g.saveReplay()
+	}
+
+	if g.winner == 1 || g.winner == 2 {
+		g.bannerTween = &anim.Tween{
+			Start:  image.Pt(0, -20),
+			End:    image.Pt(0, 0),
+			Frames: winBannerFrames,
+		}
+		g.anim.Add(g.bannerTween)
+	}
+}
+
+// recordHistory appends the just-finished game to the persisted match
+// history, rotating out the oldest entry once the ring is full. Called at the
+// g.winner != 0 transition after a human or CPU move rather than from Move
+// itself, so Move's only disk write is saveReplay (zkproof builds only).
+func (g *Game) recordHistory() {
+	var rec scores.Record
+	rec.Winner = uint8(g.winner)
+	for i := range rec.Moves {
+		rec.Moves[i] = scores.UnusedMove
+	}
+	for i, m := range g.replay.Moves {
+		rec.Moves[i] = uint8(m.X*3 + m.Y)
+	}
+	rec.Timestamp = uint32(g.totalFrameCount)
+
+	h := scores.Load()
+	h.Append(rec)
+	h.Save()
 }
 
 func (g *Game) Update() {
 	g.totalFrameCount++
+	g.anim.Update()
+
+	g.frameCount = (g.frameCount + 1) % 30
+	if g.frameCount == 0 {
+		g.animFrame = (g.animFrame + 1) % 2
+	}
 
 	if g.frameCount%2 == 0 && len(g.stars) > 0 {
 		for index := range g.stars {
@@ -50,11 +136,21 @@ func (g *Game) Update() {
 		}
 	}
 
+	if !g.difficultyChosen {
+		g.updateMenu()
+		return
+	}
+
+	if !g.lobbyDone {
+		g.updateLobby()
+		return
+	}
+
 	switch g.winner {
 	case 0:
 		{
-			button := utils.JustPressedGamepad(0)
-			if button != 0 && len(g.stars) < 1 {
+			pad0 := utils.JustPressedGamepad(0)
+			if pad0 != 0 && len(g.stars) < 1 {
 				g.rnd = Random(uint(g.totalFrameCount))
 				g.stars = make([]Star, g.rnd(160)+160)
 				for index := range g.stars {
@@ -66,38 +162,72 @@ func (g *Game) Update() {
 				}
 			}
 
-			switch button {
-			case w4.BUTTON_LEFT:
-				g.cursor.X--
-				if g.cursor.X < 0 {
-					g.cursor.X = 2
-				}
+			// Only the seat whose turn it is may move. WASM-4 netplay is
+			// lockstep-by-state: every connected instance runs this same
+			// Update with the same four gamepad registers, so the chosen
+			// (x, y) reaches every peer as ordinary game state once Move
+			// commits it — no separate netplay message is sent.
+			// Gamepad 0's presses were already read above, so reuse them
+			// rather than reading (and getting nothing from) it twice.
+			button := pad0
+			if seat := g.seatForPlayer(g.currentPlayer); seat != 0 {
+				button = utils.JustPressedGamepad(byte(seat))
+			}
 
-			case w4.BUTTON_RIGHT:
-				g.cursor.X = (g.cursor.X + 1) % 3
+			if g.cursorTween == nil {
+				switch button {
+				case w4.BUTTON_LEFT:
+					g.cursor.X--
+					if g.cursor.X < 0 {
+						g.cursor.X = 2
+					}
 
-			case w4.BUTTON_UP:
-				g.cursor.Y--
-				if g.cursor.Y < 0 {
-					g.cursor.Y = 2
-				}
+				case w4.BUTTON_RIGHT:
+					g.cursor.X = (g.cursor.X + 1) % 3
+
+				case w4.BUTTON_UP:
+					g.cursor.Y--
+					if g.cursor.Y < 0 {
+						g.cursor.Y = 2
+					}
 
-			case w4.BUTTON_DOWN:
-				g.cursor.Y = (g.cursor.Y + 1) % 3
+				case w4.BUTTON_DOWN:
+					g.cursor.Y = (g.cursor.Y + 1) % 3
 
-			case w4.BUTTON_1:
-				if g.board[g.cursor.X][g.cursor.Y] == 0 {
-					g.Move(g.cursor.X, g.cursor.Y)
+				case w4.BUTTON_1:
+					if g.board[g.cursor.X][g.cursor.Y] == 0 {
+						g.Move(g.cursor.X, g.cursor.Y)
+						if g.winner != 0 {
+							g.recordHistory()
+						}
+					}
+				case w4.BUTTON_2:
+					g.startCPUMove()
 				}
-			case w4.BUTTON_2:
-				X, Y := g.CPU()
-				g.cursor = image.Pt(X, Y)
-				g.Move(X, Y)
 			}
 		}
 
 	default:
-		if utils.JustPressedGamepad(0) != 0 {
+		if g.historyMode {
+			g.updateHistory()
+			return
+		}
+
+		if *w4.GAMEPAD1&w4.BUTTON_2 != 0 {
+			g.historyHeld++
+			if g.historyHeld == historyHoldThreshold {
+				g.historyMode = true
+				g.history = scores.Load()
+				g.historyScroll = 0
+				g.historyPlaying = -1
+				return
+			}
+		} else {
+			g.historyHeld = 0
+		}
+
+		switch utils.JustPressedGamepad(0) {
+		case w4.BUTTON_1:
 			g.board = [3][3]int{
 				{0, 0, 0},
 				{0, 0, 0},
@@ -105,10 +235,117 @@ func (g *Game) Update() {
 			}
 			g.currentPlayer = g.winner%2 + 1
 			g.winner = 0
+			g.replay = Replay{}
+		}
+	}
+}
+
+// updateHistory drives the match-history screen entered by holding
+// BUTTON_2 on the game-over screen: BUTTON_UP/BUTTON_DOWN scroll the list,
+// BUTTON_1 steps the mini-board through the selected row's recorded moves,
+// and BUTTON_2 exits back to the game-over screen.
+func (g *Game) updateHistory() {
+	switch utils.JustPressedGamepad(0) {
+	case w4.BUTTON_UP:
+		if g.historyScroll > 0 {
+			g.historyScroll--
+		}
+		g.historyPlaying = -1
+	case w4.BUTTON_DOWN:
+		if g.historyScroll < g.history.Len()-1 {
+			g.historyScroll++
+		}
+		g.historyPlaying = -1
+	case w4.BUTTON_1:
+		if g.historyPlaying < 8 {
+			g.historyPlaying++
 		}
+	case w4.BUTTON_2:
+		g.historyMode = false
+		g.historyHeld = 0
+	}
+}
+
+// startCPUMove asks the CPU for its move and tweens the cursor from its
+// current cell to the chosen one over cpuMoveFrames, committing the move
+// with g.Move only once the cursor lands.
+func (g *Game) startCPUMove() {
+	X, Y := g.CPU()
+	target := image.Pt(X, Y)
+	start := g.cursor
+
+	g.cursorTween = &anim.Tween{
+		Start:  image.Pt(0, 0),
+		End:    image.Pt((target.X-start.X)*cellPixels, (target.Y-start.Y)*cellPixels),
+		Frames: cpuMoveFrames,
+		OnDone: func() {
+			g.cursor = target
+			g.Move(target.X, target.Y)
+			if g.winner != 0 {
+				g.recordHistory()
+			}
+			g.cursorTween = nil
+		},
+	}
+	g.anim.Add(g.cursorTween)
+}
+
+// updateMenu lets the human pick a CPU difficulty before the first move.
+func (g *Game) updateMenu() {
+	switch utils.JustPressedGamepad(0) {
+	case w4.BUTTON_UP, w4.BUTTON_DOWN, w4.BUTTON_LEFT, w4.BUTTON_RIGHT:
+		g.difficulty = (g.difficulty + 1) % 3
+	case w4.BUTTON_1, w4.BUTTON_2:
+		g.difficultyChosen = true
 	}
 }
 
+// updateLobby lets each of the four netplay seats claim X or O with
+// BUTTON_2 before the game starts. Any seat can press BUTTON_1 to start
+// without claiming a side, so a single local gamepad can still play both
+// sides hot-seat style exactly as before netplay existed.
+func (g *Game) updateLobby() {
+	for seat := 0; seat < 4; seat++ {
+		switch utils.JustPressedGamepad(byte(seat)) {
+		case w4.BUTTON_2:
+			switch {
+			case !g.seatClaimed(1):
+				g.seatPlayer[seat] = 1
+			case !g.seatClaimed(2):
+				g.seatPlayer[seat] = 2
+			}
+		case w4.BUTTON_1:
+			g.lobbyDone = true
+		}
+	}
+}
+
+// seatClaimed reports whether some seat has already claimed player.
+func (g *Game) seatClaimed(player int) bool {
+	return g.claimedSeat(player) >= 0
+}
+
+// claimedSeat returns the netplay seat that claimed player in the lobby, or
+// -1 if no seat has.
+func (g *Game) claimedSeat(player int) int {
+	for seat, p := range g.seatPlayer {
+		if p == player {
+			return seat
+		}
+	}
+	return -1
+}
+
+// seatForPlayer returns the gamepad seat that should drive player's turn:
+// whichever seat claimed it in the lobby, or seat 0 if none did, so a
+// single local gamepad can still play both sides hot-seat style.
+func (g *Game) seatForPlayer(player int) int {
+	if seat := g.claimedSeat(player); seat >= 0 {
+		return seat
+	}
+	return 0
+}
+
 func (g *Game) Draw() {
 	for _, star := range g.stars {
 		*w4.DRAW_COLORS = 4 - uint16(star.VelX)
@@ -119,9 +356,19 @@ func (g *Game) Draw() {
 
 	board.Draw()
 
-	g.frameCount = (g.frameCount + 1) % 30
-	if g.frameCount == 0 {
-		g.animFrame = (g.animFrame + 1) % 2
+	if !g.difficultyChosen {
+		g.drawMenu()
+		return
+	}
+
+	if !g.lobbyDone {
+		g.drawLobby()
+		return
+	}
+
+	if g.historyMode {
+		g.drawHistory()
+		return
 	}
 
 	for x := range g.board {
@@ -132,7 +379,7 @@ func (g *Game) Draw() {
 				win = true
 			}
 			if playerID != 0 {
-				playerSprite[playerID-1].Draw(image.Point{X: x, Y: y}, win)
+				playerSprite[playerID-1].Draw(image.Point{X: x, Y: y}, win, image.Point{})
 			}
 		}
 	}
@@ -145,13 +392,21 @@ func (g *Game) Draw() {
 	letters["4"].Draw(14, 18)
 
 	if g.winner == 1 || g.winner == 2 {
-		nextSprite[g.winner-1].Draw()
+		bannerOffset := image.Pt(0, 0)
+		if g.bannerTween != nil {
+			bannerOffset, _ = g.bannerTween.At()
+		}
+		nextSprite[g.winner-1].Draw(bannerOffset)
 		letters["W"].Draw(8, 0)
 		letters["I"].Draw(10, 0)
 		letters["N"].Draw(11, 0)
 		letters["S"].Draw(13, 0)
 	} else if g.winner == 0 {
-		playerSprite[g.currentPlayer-1].Draw(g.cursor, g.animFrame == 0)
+		cursorOffset := image.Pt(0, 0)
+		if g.cursorTween != nil {
+			cursorOffset, _ = g.cursorTween.At()
+		}
+		playerSprite[g.currentPlayer-1].Draw(g.cursor, g.animFrame == 0, cursorOffset)
 	} else {
 		letters["D"].Draw(6, 0)
 		letters["R"].Draw(8, 0)
@@ -160,9 +415,104 @@ func (g *Game) Draw() {
 	}
 }
 
+// drawMenu renders the Easy/Normal/Hard difficulty picker as a row of bars,
+// one per Difficulty value, with the selected bar highlighted.
+func (g *Game) drawMenu() {
+	for d := Easy; d <= Hard; d++ {
+		*w4.DRAW_COLORS = 0x33
+		if d == g.difficulty {
+			*w4.DRAW_COLORS = 0x44
+		}
+		w4.Rect(48+int(d)*24, 76, 16, 8)
+	}
+
+	playerSprite[g.currentPlayer-1].Draw(image.Pt(1, 1), g.animFrame == 0, image.Point{})
+}
+
+// drawLobby renders the netplay seat picker: each side's sprite plus a bar
+// (reusing the drawMenu indicator style) that lights up once a seat has
+// claimed it, the word "MAIN" from the existing letters map as a title, and
+// a further highlight on whichever side this local instance has claimed.
+func (g *Game) drawLobby() {
+	letters["M"].Draw(6, 2)
+	letters["A"].Draw(8, 2)
+	letters["I"].Draw(10, 2)
+	letters["N"].Draw(11, 2)
+
+	localPlayer := g.seatPlayer[utils.LocalPlayerIndex()]
+
+	for player := 1; player <= 2; player++ {
+		*w4.DRAW_COLORS = 0x33
+		if g.seatClaimed(player) {
+			*w4.DRAW_COLORS = 0x44
+		}
+		if player == localPlayer {
+			*w4.DRAW_COLORS = 0x22
+		}
+		w4.Rect(48+(player-1)*24, 50, 16, 8)
+
+		playerSprite[player-1].Draw(image.Pt(player, 1), false, image.Point{})
+	}
+}
+
+// drawHistory renders the match-history screen: a scrollable column of past
+// winners using the existing letters map and nextSprite icons, plus a
+// step-by-step mini-board replay of the top visible row's recorded moves.
+func (g *Game) drawHistory() {
+	letters["M"].Draw(6, 0)
+	letters["A"].Draw(8, 0)
+	letters["I"].Draw(10, 0)
+	letters["N"].Draw(11, 0)
+
+	const visibleRows = 6
+	for row := 0; row < visibleRows && g.historyScroll+row < g.history.Len(); row++ {
+		rec := g.history.At(g.historyScroll + row)
+		rowOffset := image.Pt(-40, 16+row*8)
+
+		switch rec.Winner {
+		case 1, 2:
+			nextSprite[rec.Winner-1].Draw(rowOffset)
+		case 3:
+			letters["D"].Draw(2, 2+row)
+		}
+	}
+
+	if g.history.Len() == 0 || g.historyPlaying < 0 {
+		return
+	}
+
+	rec := g.history.At(g.historyScroll)
+	var miniBoard [3][3]int
+	for i := 0; i <= g.historyPlaying && i < len(rec.Moves); i++ {
+		cell := rec.Moves[i]
+		if cell == scores.UnusedMove {
+			break
+		}
+		miniBoard[cell/3][cell%3] = i%2 + 1
+	}
+
+	for x := range miniBoard {
+		for y, playerID := range miniBoard[x] {
+			if playerID != 0 {
+				playerSprite[playerID-1].Draw(image.Point{X: x, Y: y}, false, image.Pt(40, 0))
+			}
+		}
+	}
+}
+
+// CheckStatus reports the status of g.board: 0 in progress, 1 or 2 if that
+// player has three in a row (with pos set to the winning cells), or 3 for a
+// draw.
 func (g *Game) CheckStatus() (playerID int, pos [3]image.Point) {
+	return checkStatus(g.board)
+}
+
+// checkStatus is the pure board-evaluation logic behind Game.CheckStatus,
+// taking the board by value so it can also back VerifyReplay's side-effect-
+// free replay without going through Game.Move.
+func checkStatus(board [3][3]int) (playerID int, pos [3]image.Point) {
 	for y := 0; y < 3; y++ {
-		if id := g.board[0][y]; id != 0 && id == g.board[1][y] && id == g.board[2][y] {
+		if id := board[0][y]; id != 0 && id == board[1][y] && id == board[2][y] {
 			pos[0] = image.Pt(0, y)
 			pos[1] = image.Pt(1, y)
 			pos[2] = image.Pt(2, y)
@@ -171,7 +521,7 @@ func (g *Game) CheckStatus() (playerID int, pos [3]image.Point) {
 	}
 
 	for x := 0; x < 3; x++ {
-		if id := g.board[x][0]; id != 0 && id == g.board[x][1] && id == g.board[x][2] {
+		if id := board[x][0]; id != 0 && id == board[x][1] && id == board[x][2] {
 			pos[0] = image.Pt(x, 0)
 			pos[1] = image.Pt(x, 1)
 			pos[2] = image.Pt(x, 2)
@@ -179,14 +529,14 @@ func (g *Game) CheckStatus() (playerID int, pos [3]image.Point) {
 		}
 	}
 
-	if id := g.board[0][0]; id != 0 && id == g.board[1][1] && id == g.board[2][2] {
+	if id := board[0][0]; id != 0 && id == board[1][1] && id == board[2][2] {
 		pos[0] = image.Pt(0, 0)
 		pos[1] = image.Pt(1, 1)
 		pos[2] = image.Pt(2, 2)
 		return id, pos
 	}
 
-	if id := g.board[2][0]; id != 0 && id == g.board[1][1] && id == g.board[0][2] {
+	if id := board[2][0]; id != 0 && id == board[1][1] && id == board[0][2] {
 		pos[0] = image.Pt(2, 0)
 		pos[1] = image.Pt(1, 1)
 		pos[2] = image.Pt(0, 2)
@@ -195,7 +545,7 @@ func (g *Game) CheckStatus() (playerID int, pos [3]image.Point) {
 
 	for x := 0; x < 3; x++ {
 		for y := 0; y < 3; y++ {
-			if g.board[x][y] == 0 {
+			if board[x][y] == 0 {
 				return 0, pos
 			}
 		}
@@ -204,7 +554,126 @@ func (g *Game) CheckStatus() (playerID int, pos [3]image.Point) {
 	return 3, pos
 }
 
+// CPU picks the next move for g.currentPlayer according to g.difficulty.
 func (g *Game) CPU() (int, int) {
+	switch g.difficulty {
+	case Normal:
+		x, y, _ := g.searchMove(g.currentPlayer, normalSearchDepth)
+		return x, y
+	case Hard:
+		x, y, _ := g.BestMove(g.currentPlayer)
+		return x, y
+	default:
+		return g.easyMove()
+	}
+}
+
+// BestMove runs a full-depth search for player and returns the chosen cell
+// together with its score, independent of rendering or g.difficulty.
+func (g *Game) BestMove(player int) (x, y, score int) {
+	return g.searchMove(player, 9)
+}
+
+// searchMove is the alpha-beta search shared by Normal (depth-limited) and
+// Hard (full-depth) difficulty.
+func (g *Game) searchMove(player, maxDepth int) (x, y, score int) {
+	bestX, bestY := -1, -1
+	best := math.MinInt32
+
+	for X := 0; X < 3; X++ {
+		for Y := 0; Y < 3; Y++ {
+			if g.board[X][Y] != 0 {
+				continue
+			}
+
+			g.board[X][Y] = player
+			s := g.minimax(1, maxDepth, false, player, math.MinInt32, math.MaxInt32)
+			g.board[X][Y] = 0
+
+			if s > best {
+				best, bestX, bestY = s, X, Y
+			}
+		}
+	}
+
+	return bestX, bestY, best
+}
+
+// minimax scores the current board from player's perspective: +10-depth if
+// player wins, depth-10 if the opponent wins, 0 for a draw. maximizing is
+// true when it's player's turn to move. Search stops early once
+// alpha >= beta, and is truncated to a neutral 0 past maxDepth.
+func (g *Game) minimax(depth, maxDepth int, maximizing bool, player, alpha, beta int) int {
+	if id, _ := g.CheckStatus(); id != 0 {
+		switch id {
+		case player:
+			return 10 - depth
+		case 3:
+			return 0
+		default:
+			return depth - 10
+		}
+	}
+
+	if depth >= maxDepth {
+		return 0
+	}
+
+	toMove := player
+	if !maximizing {
+		toMove = player%2 + 1
+	}
+
+	if maximizing {
+		best := math.MinInt32
+		for X := 0; X < 3; X++ {
+			for Y := 0; Y < 3; Y++ {
+				if g.board[X][Y] != 0 {
+					continue
+				}
+				g.board[X][Y] = toMove
+				score := g.minimax(depth+1, maxDepth, false, player, alpha, beta)
+				g.board[X][Y] = 0
+
+				if score > best {
+					best = score
+				}
+				if best > alpha {
+					alpha = best
+				}
+				if alpha >= beta {
+					return best
+				}
+			}
+		}
+		return best
+	}
+
+	best := math.MaxInt32
+	for X := 0; X < 3; X++ {
+		for Y := 0; Y < 3; Y++ {
+			if g.board[X][Y] != 0 {
+				continue
+			}
+			g.board[X][Y] = toMove
+			score := g.minimax(depth+1, maxDepth, true, player, alpha, beta)
+			g.board[X][Y] = 0
+
+			if score < best {
+				best = score
+			}
+			if best < beta {
+				beta = best
+			}
+			if alpha >= beta {
+				return best
+			}
+		}
+	}
+	return best
+}
+
+func (g *Game) easyMove() (int, int) {
 	nextPlayer := g.currentPlayer%2 + 1
 
 	// Check if the current player can win
@@ -250,5 +719,6 @@ func (g *Game) CPU() (int, int) {
 }
 
 var game = &Game{
-	currentPlayer: 1,
+	currentPlayer:  1,
+	historyPlaying: -1,
 }
@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+// TestBestMoveWins checks that BestMove takes an immediate win when one is
+// available, even with other reasonable-looking cells open.
+func TestBestMoveWins(t *testing.T) {
+	g := &Game{
+		board: [3][3]int{
+			{1, 1, 0},
+			{2, 0, 0},
+			{0, 2, 0},
+		},
+	}
+
+	x, y, _ := g.BestMove(1)
+	if x != 0 || y != 2 {
+		t.Fatalf("BestMove(1) = (%d, %d), want (0, 2)", x, y)
+	}
+}
+
+// TestBestMoveBlocks checks that BestMove blocks the opponent's win when it
+// has no win of its own available.
+func TestBestMoveBlocks(t *testing.T) {
+	g := &Game{
+		board: [3][3]int{
+			{2, 2, 0},
+			{1, 0, 0},
+			{0, 1, 0},
+		},
+	}
+
+	x, y, _ := g.BestMove(1)
+	if x != 0 || y != 2 {
+		t.Fatalf("BestMove(1) = (%d, %d), want (0, 2)", x, y)
+	}
+}
+
+// TestSearchMoveDepthLimited checks that searchMove still finds an
+// immediate win within maxDepth, mirroring how CPU uses it for Normal
+// difficulty.
+func TestSearchMoveDepthLimited(t *testing.T) {
+	g := &Game{
+		board: [3][3]int{
+			{1, 1, 0},
+			{2, 0, 0},
+			{0, 2, 0},
+		},
+	}
+
+	x, y, score := g.searchMove(1, 1)
+	if x != 0 || y != 2 {
+		t.Fatalf("searchMove(1, 1) = (%d, %d), want (0, 2)", x, y)
+	}
+	if score <= 0 {
+		t.Fatalf("searchMove(1, 1) score = %d, want a winning (positive) score", score)
+	}
+}
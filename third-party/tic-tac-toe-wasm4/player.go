@@ -17,14 +17,16 @@ type Player struct {
 
 type Next []Tile
 
-func (p *Player) Draw(pos image.Point, win bool) {
+// Draw renders p at the given grid cell, shifted by offset pixels so a
+// running Tween can animate it between cells instead of snapping to pos.
+func (p *Player) Draw(pos image.Point, win bool, offset image.Point) {
 	ts := p.TilesNormal
 	if win {
 		ts = p.TilesWin
 	}
 	for _, tile := range ts {
-		x := tile.Pos.X*8 + (pos.X*6+2)*8
-		y := tile.Pos.Y*8 + (pos.Y*6+2)*8
+		x := tile.Pos.X*8 + (pos.X*6+2)*8 + offset.X
+		y := tile.Pos.Y*8 + (pos.Y*6+2)*8 + offset.Y
 		sx := uint(tile.ID%12) * 8
 		sy := uint(tile.ID/12) * 8
 		w4.BlitSub(&tileset.Data[0], x, y, 8, 8, sx, sy, tileset.Width, tileset.Flags)
@@ -32,10 +34,12 @@ func (p *Player) Draw(pos image.Point, win bool) {
 
 }
 
-func (n Next) Draw() {
+// Draw renders n at its fixed screen position, shifted by offset pixels so
+// a running Tween can slide it in rather than snapping into place.
+func (n Next) Draw(offset image.Point) {
 	for _, tile := range n {
-		x := tile.Pos.X*8 + 5*8
-		y := tile.Pos.Y * 8
+		x := tile.Pos.X*8 + 5*8 + offset.X
+		y := tile.Pos.Y*8 + offset.Y
 		sx := uint(tile.ID%12) * 8
 		sy := uint(tile.ID/12) * 8
 		w4.BlitSub(&tileset.Data[0], x, y, 8, 8, sx, sy, tileset.Width, tileset.Flags)
@@ -0,0 +1,57 @@
+package main
+
+import "image"
+
+// Move is a single ply of a recorded game, as played by Game.Move.
+type Move struct {
+	Player int
+	X, Y   int
+}
+
+// Replay is the full move history of one game, captured incrementally by
+// Game.Move so the game can be re-verified deterministically off of the
+// move list alone, without any rendering state.
+type Replay struct {
+	Moves  []Move
+	Winner int
+	Pos    [3]image.Point
+}
+
+// record appends a ply to the replay; called from Game.Move on every move.
+func (r *Replay) record(player, x, y int) {
+	r.Moves = append(r.Moves, Move{Player: player, X: x, Y: y})
+}
+
+// VerifyReplay re-plays r.Moves on a bare board, using only the pure
+// checkStatus logic behind Game.CheckStatus, and reports whether the replay
+// legally reaches its claimed winner. The opening player is taken from the
+// first move (either side may open, as games alternate who starts) and the
+// players must alternate from there. ProveReplay calls this, and its win
+// detection is the same checkStatus the game runs; it deliberately avoids
+// Game.Move, which records into g.replay and calls saveReplay (a disk write
+// under the zkproof tag).
+func VerifyReplay(r Replay) (winner int, ok bool) {
+	var board [3][3]int
+	currentPlayer := 1
+	if len(r.Moves) > 0 && r.Moves[0].Player == 2 {
+		currentPlayer = 2
+	}
+
+	for _, m := range r.Moves {
+		if winner != 0 {
+			return 0, false
+		}
+		if m.X < 0 || m.X > 2 || m.Y < 0 || m.Y > 2 {
+			return 0, false
+		}
+		if m.Player != currentPlayer || board[m.X][m.Y] != 0 {
+			return 0, false
+		}
+
+		board[m.X][m.Y] = currentPlayer
+		currentPlayer = currentPlayer%2 + 1
+		winner, _ = checkStatus(board)
+	}
+
+	return winner, winner == r.Winner
+}
@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+// TestVerifyReplayWin checks that a legal replay ending in three in a row
+// verifies with the claimed winner.
+func TestVerifyReplayWin(t *testing.T) {
+	r := Replay{
+		Moves: []Move{
+			{Player: 1, X: 0, Y: 0},
+			{Player: 2, X: 1, Y: 0},
+			{Player: 1, X: 0, Y: 1},
+			{Player: 2, X: 1, Y: 1},
+			{Player: 1, X: 0, Y: 2},
+		},
+		Winner: 1,
+	}
+
+	winner, ok := VerifyReplay(r)
+	if !ok || winner != 1 {
+		t.Fatalf("VerifyReplay(r) = (%d, %v), want (1, true)", winner, ok)
+	}
+}
+
+// TestVerifyReplayRejectsOutOfTurn checks that a replay where a player moves
+// twice in a row is rejected.
+func TestVerifyReplayRejectsOutOfTurn(t *testing.T) {
+	r := Replay{
+		Moves: []Move{
+			{Player: 1, X: 0, Y: 0},
+			{Player: 1, X: 1, Y: 1},
+		},
+		Winner: 0,
+	}
+
+	if _, ok := VerifyReplay(r); ok {
+		t.Fatal("VerifyReplay(r) = ok, want rejection of an out-of-turn move")
+	}
+}
+
+// TestVerifyReplayRejectsOccupiedCell checks that a replay claiming a move
+// onto an already-occupied cell is rejected.
+func TestVerifyReplayRejectsOccupiedCell(t *testing.T) {
+	r := Replay{
+		Moves: []Move{
+			{Player: 1, X: 0, Y: 0},
+			{Player: 2, X: 0, Y: 0},
+		},
+		Winner: 0,
+	}
+
+	if _, ok := VerifyReplay(r); ok {
+		t.Fatal("VerifyReplay(r) = ok, want rejection of a move onto an occupied cell")
+	}
+}
+
+// TestVerifyReplayRejectsOutOfRangeCoords checks that a replay with a move
+// coordinate outside the board is rejected instead of panicking.
+func TestVerifyReplayRejectsOutOfRangeCoords(t *testing.T) {
+	r := Replay{
+		Moves: []Move{
+			{Player: 1, X: 5, Y: 5},
+		},
+		Winner: 0,
+	}
+
+	if _, ok := VerifyReplay(r); ok {
+		t.Fatal("VerifyReplay(r) = ok, want rejection of an out-of-range move")
+	}
+}
+
+// TestVerifyReplayRejectsWrongClaimedWinner checks that a legally-played
+// replay still fails verification if Winner doesn't match the actual
+// outcome.
+func TestVerifyReplayRejectsWrongClaimedWinner(t *testing.T) {
+	r := Replay{
+		Moves: []Move{
+			{Player: 1, X: 0, Y: 0},
+			{Player: 2, X: 1, Y: 0},
+			{Player: 1, X: 0, Y: 1},
+			{Player: 2, X: 1, Y: 1},
+			{Player: 1, X: 0, Y: 2},
+		},
+		Winner: 2,
+	}
+
+	if winner, ok := VerifyReplay(r); ok || winner != 1 {
+		t.Fatalf("VerifyReplay(r) = (%d, %v), want (1, false)", winner, ok)
+	}
+}
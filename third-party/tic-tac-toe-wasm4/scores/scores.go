@@ -0,0 +1,115 @@
+// Package scores persists a fixed-size ring of past games to WASM-4 disk
+// storage, modeled on classic fantasy-console score tables.
+package scores
+
+import "cart/w4"
+
+// historySize is how many past games the ring remembers; historySize
+// records at recordSize bytes plus a 2-byte ring header comfortably fit
+// WASM-4's 1024-byte disk budget.
+const historySize = 64
+
+// recordSize is the packed, on-disk size of a Record: 1 Winner byte, 9
+// Moves bytes, 4 Timestamp bytes.
+const recordSize = 1 + 9 + 4
+
+// UnusedMove marks a Moves slot that was never played.
+const UnusedMove = 0xFF
+
+// Record is one completed game. Moves encodes each turn's cell as x*3+y,
+// in play order, with UnusedMove padding the remaining slots.
+type Record struct {
+	Winner    uint8
+	Moves     [9]uint8
+	Timestamp uint32
+}
+
+// History is the persisted ring of past games, oldest-first.
+type History struct {
+	records [historySize]Record
+	count   int
+	head    int // index of the oldest record once count == historySize
+}
+
+// Len reports how many records are stored.
+func (h *History) Len() int {
+	return h.count
+}
+
+// At returns the i-th record in play order, oldest first.
+func (h *History) At(i int) Record {
+	if h.count < historySize {
+		return h.records[i]
+	}
+	return h.records[(h.head+i)%historySize]
+}
+
+// Append adds r to the ring, rotating out the oldest record once full.
+func (h *History) Append(r Record) {
+	if h.count < historySize {
+		h.records[h.count] = r
+		h.count++
+		return
+	}
+	h.records[h.head] = r
+	h.head = (h.head + 1) % historySize
+}
+
+// diskSize is the flat on-disk layout: a 2-byte ring header (count, head)
+// followed by historySize packed records.
+const diskSize = 2 + historySize*recordSize
+
+// Load reads the ring back from WASM-4 disk storage. A short or missing
+// save (e.g. first boot) yields an empty History.
+func Load() History {
+	var buf [diskSize]byte
+	var h History
+
+	if w4.DiskR(&buf[0], uint32(len(buf))) < 2 {
+		return h
+	}
+
+	h.count = int(buf[0])
+	h.head = int(buf[1])
+	if h.count > historySize {
+		h.count = historySize
+	}
+
+	for i := 0; i < h.count; i++ {
+		off := 2 + i*recordSize
+		h.records[i] = decode(buf[off : off+recordSize])
+	}
+
+	return h
+}
+
+// Save writes the ring to WASM-4 disk storage.
+func (h *History) Save() {
+	var buf [diskSize]byte
+	buf[0] = byte(h.count)
+	buf[1] = byte(h.head)
+
+	for i := 0; i < h.count; i++ {
+		off := 2 + i*recordSize
+		encode(buf[off:off+recordSize], h.records[i])
+	}
+
+	w4.DiskW(&buf[0], uint32(len(buf)))
+}
+
+func encode(dst []byte, r Record) {
+	dst[0] = r.Winner
+	copy(dst[1:10], r.Moves[:])
+	dst[10] = byte(r.Timestamp)
+	dst[11] = byte(r.Timestamp >> 8)
+	dst[12] = byte(r.Timestamp >> 16)
+	dst[13] = byte(r.Timestamp >> 24)
+}
+
+func decode(src []byte) Record {
+	var r Record
+	r.Winner = src[0]
+	copy(r.Moves[:], src[1:10])
+	r.Timestamp = uint32(src[10]) | uint32(src[11])<<8 | uint32(src[12])<<16 | uint32(src[13])<<24
+	return r
+}
@@ -0,0 +1,43 @@
+package scores
+
+import "testing"
+
+// TestHistoryAppendOrder checks that Append keeps records in play order,
+// oldest first, while the ring is not yet full.
+func TestHistoryAppendOrder(t *testing.T) {
+	var h History
+	h.Append(Record{Winner: 1, Timestamp: 1})
+	h.Append(Record{Winner: 2, Timestamp: 2})
+	h.Append(Record{Winner: 3, Timestamp: 3})
+
+	if h.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", h.Len())
+	}
+	for i, want := range []uint8{1, 2, 3} {
+		if got := h.At(i).Winner; got != want {
+			t.Fatalf("At(%d).Winner = %d, want %d", i, got, want)
+		}
+	}
+}
+
+// TestHistoryRotation checks that once the ring is full, Append rotates out
+// the oldest record and Len stops growing.
+func TestHistoryRotation(t *testing.T) {
+	var h History
+	for i := 0; i < historySize+2; i++ {
+		h.Append(Record{Timestamp: uint32(i)})
+	}
+
+	if h.Len() != historySize {
+		t.Fatalf("Len() = %d, want %d", h.Len(), historySize)
+	}
+
+	// The two oldest records (timestamps 0 and 1) should have rotated out,
+	// so the oldest surviving record is timestamp 2.
+	if got := h.At(0).Timestamp; got != 2 {
+		t.Fatalf("At(0).Timestamp = %d, want 2", got)
+	}
+	if got := h.At(h.Len() - 1).Timestamp; got != uint32(historySize+1) {
+		t.Fatalf("At(Len()-1).Timestamp = %d, want %d", got, historySize+1)
+	}
+}
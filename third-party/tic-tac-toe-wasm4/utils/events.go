@@ -33,9 +33,9 @@ func IsGamepadJustPressed(gamepadID, key byte) bool {
 		gamepad = *w4.GAMEPAD1
 	case 1:
 		gamepad = *w4.GAMEPAD2
-	case 3:
+	case 2:
 		gamepad = *w4.GAMEPAD3
-	case 4:
+	case 3:
 		gamepad = *w4.GAMEPAD4
 	default:
 		return false
@@ -57,9 +57,9 @@ func JustPressedGamepad(gamepadID byte) byte {
 		gamepad = *w4.GAMEPAD1
 	case 1:
 		gamepad = *w4.GAMEPAD2
-	case 3:
+	case 2:
 		gamepad = *w4.GAMEPAD3
-	case 4:
+	case 3:
 		gamepad = *w4.GAMEPAD4
 	default:
 		return 0
@@ -70,3 +70,9 @@ func JustPressedGamepad(gamepadID byte) byte {
 
 	return result
 }
+
+// LocalPlayerIndex returns which of the (up to 4) netplay seats this WASM-4
+// instance is driving locally, read from the low 2 bits of w4.NETPLAY.
+func LocalPlayerIndex() int {
+	return int(*w4.NETPLAY & 0b11)
+}
@@ -0,0 +1,78 @@
+//go:build zkproof
+
+package main
+
+import (
+	"cart/w4"
+	"image"
+)
+
+// maxReplayBytes bounds the flat encoding below: a move count byte, up to
+// nine (player, x, y) moves, a winner byte, then three (x, y) line-positions.
+const maxReplayBytes = 1 + 9*3 + 1 + 3*2
+
+// saveReplay serializes g.replay into a flat byte buffer and writes it to
+// WASM-4's persistent disk storage, so zkEngine can later decode it with
+// LoadReplay and prove the recorded moves legally reach a winning line.
+func (g *Game) saveReplay() {
+	var buf [maxReplayBytes]byte
+
+	buf[0] = byte(len(g.replay.Moves))
+	off := 1
+	for _, m := range g.replay.Moves {
+		buf[off], buf[off+1], buf[off+2] = byte(m.Player), byte(m.X), byte(m.Y)
+		off += 3
+	}
+
+	buf[off] = byte(g.replay.Winner)
+	off++
+	for _, p := range g.replay.Pos {
+		buf[off], buf[off+1] = byte(p.X), byte(p.Y)
+		off += 2
+	}
+
+	w4.DiskW(&buf[0], uint32(off))
+}
+
+// LoadReplay decodes the flat byte buffer written by saveReplay back into a
+// Replay, mirroring its encoding exactly. It reports ok=false, rather than
+// indexing past the end of buf, if buf is too short for the move count its
+// own first byte claims.
+func LoadReplay(buf []byte) (r Replay, ok bool) {
+	if len(buf) < 1 {
+		return Replay{}, false
+	}
+
+	n := int(buf[0])
+	off := 1
+	if len(buf) < off+n*3+1+len(r.Pos)*2 {
+		return Replay{}, false
+	}
+
+	for i := 0; i < n; i++ {
+		r.Moves = append(r.Moves, Move{Player: int(buf[off]), X: int(buf[off+1]), Y: int(buf[off+2])})
+		off += 3
+	}
+
+	r.Winner = int(buf[off])
+	off++
+	for i := range r.Pos {
+		r.Pos[i] = image.Pt(int(buf[off]), int(buf[off+1]))
+		off += 2
+	}
+
+	return r, true
+}
+
+// ProveReplay is the host-side entry point zkEngine calls after executing
+// this cart under the zkproof tag: it decodes the replay written to WASM-4
+// disk storage and checks it with VerifyReplay, whose win detection is the
+// same checkStatus the game runs, so the proof attests that player P legally
+// reached a winning line.
+func ProveReplay(buf []byte) (winner int, ok bool) {
+	r, ok := LoadReplay(buf)
+	if !ok {
+		return 0, false
+	}
+	return VerifyReplay(r)
+}
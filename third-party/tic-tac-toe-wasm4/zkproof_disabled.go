@@ -0,0 +1,7 @@
+//go:build !zkproof
+
+package main
+
+// saveReplay is a no-op unless the zkproof build tag is set, in which case
+// it persists g.replay to WASM-4 disk storage for later proving.
+func (g *Game) saveReplay() {}